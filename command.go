@@ -0,0 +1,79 @@
+package envy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// Command returns an *exec.Cmd for name with args, pre-populated with
+// Env: Environ() so the child process inherits envy's overlay (including
+// anything set via Set) rather than the pristine OS environment.
+func Command(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.Env = Environ()
+	return cmd
+}
+
+// CommandContext is like Command, but the returned *exec.Cmd is bound to
+// ctx: it is killed if ctx is done before it completes.
+func CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = Environ()
+	return cmd
+}
+
+// Invocation describes a single `go` subcommand invocation for Run, modeled
+// on golang.org/x/tools/internal/gocommand.Invocation.
+type Invocation struct {
+	// Verb is the go subcommand to run, e.g. "build" or "test".
+	Verb string
+	// Args are the arguments to Verb.
+	Args []string
+	// BuildFlags are inserted between Verb and Args, e.g. "-mod=mod".
+	BuildFlags []string
+	// Dir is the working directory for the command, if not the caller's.
+	Dir string
+	// Env holds extra KEY=VALUE pairs layered on top of envy's Environ(),
+	// taking precedence over it.
+	Env []string
+	// Stdin, if set, is connected to the subprocess's standard input.
+	Stdin io.Reader
+}
+
+// Run executes the invocation with "go" as the command, merging envy's env
+// (via CommandContext) with any caller-supplied Env overrides, and returns
+// its captured stdout and stderr separately. A non-nil error is a
+// friendlyErr: the noisy leading "exec: " go's exec package adds is
+// stripped and, if anything was written to stderr, that output is folded
+// into the error message.
+func Run(ctx context.Context, inv Invocation) (stdout, stderr []byte, err error) {
+	args := append(append([]string{inv.Verb}, inv.BuildFlags...), inv.Args...)
+
+	cmd := CommandContext(ctx, "go", args...)
+	cmd.Dir = inv.Dir
+	cmd.Stdin = inv.Stdin
+	cmd.Env = append(cmd.Env, inv.Env...)
+
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+
+	if err := cmd.Run(); err != nil {
+		return outBuf.Bytes(), errBuf.Bytes(), friendlyErr(err, &errBuf)
+	}
+	return outBuf.Bytes(), errBuf.Bytes(), nil
+}
+
+// friendlyErr strips the "exec: " prefix exec.Error adds and, if the
+// subprocess wrote anything to stderr, folds it into the error message.
+func friendlyErr(err error, stderr *bytes.Buffer) error {
+	msg := strings.TrimPrefix(err.Error(), "exec: ")
+	if stderr.Len() == 0 {
+		return fmt.Errorf("%s", msg)
+	}
+	return fmt.Errorf("%s: %s", msg, strings.TrimSpace(stderr.String()))
+}
@@ -0,0 +1,60 @@
+package envy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandInheritsEnvyOverlay(t *testing.T) {
+	Set("CHUNK0_6_COMMAND_VAR", "command-value")
+
+	cmd := Command("go", "env")
+	require.Contains(t, cmd.Env, "CHUNK0_6_COMMAND_VAR=command-value")
+}
+
+func TestCommandContextInheritsEnvyOverlay(t *testing.T) {
+	Set("CHUNK0_6_COMMAND_CONTEXT_VAR", "command-context-value")
+
+	cmd := CommandContext(context.Background(), "go", "env")
+	require.Contains(t, cmd.Env, "CHUNK0_6_COMMAND_CONTEXT_VAR=command-context-value")
+}
+
+// `go env NAME` only prints a value for names the go tool itself
+// recognizes (GOFLAGS, GOPATH, ...) - arbitrary caller-defined vars are
+// always printed blank. So these use GOFLAGS, a real toolchain var, to
+// exercise argv assembly and env-merge precedence end to end.
+
+func TestRunAssemblesArgvAndCapturesStdout(t *testing.T) {
+	Set("GOFLAGS", "-run-test-sentinel=envy")
+
+	stdout, stderr, err := Run(context.Background(), Invocation{
+		Verb: "env",
+		Args: []string{"GOFLAGS"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, string(stderr))
+	require.Equal(t, "-run-test-sentinel=envy", strings.TrimSpace(string(stdout)))
+}
+
+func TestRunEnvOverridesTakePrecedence(t *testing.T) {
+	Set("GOFLAGS", "-from-envy=1")
+
+	stdout, _, err := Run(context.Background(), Invocation{
+		Verb: "env",
+		Args: []string{"GOFLAGS"},
+		Env:  []string{"GOFLAGS=-from-invocation=1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "-from-invocation=1", strings.TrimSpace(string(stdout)))
+}
+
+func TestRunFriendlyErrOnFailure(t *testing.T) {
+	_, _, err := Run(context.Background(), Invocation{
+		Verb: "this-is-not-a-real-go-subcommand",
+	})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "exec: ", "friendlyErr must strip the exec: prefix")
+}
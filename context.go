@@ -0,0 +1,82 @@
+package envy
+
+import (
+	"context"
+	"fmt"
+)
+
+type ctxKey struct{}
+
+// With returns a new context carrying an ENV overlay. Values in overrides
+// take precedence over the global env map (and over any overlay already
+// attached to ctx) when looked up through GetCtx, MustGetCtx, MapCtx, or
+// EnvironCtx. This lets goroutines, parallel tests, and concurrent request
+// handlers each carry their own ENV view without touching the package-global
+// map that Temp mutates.
+//
+// Nesting is supported: calling With on a context that already carries an
+// overlay merges the two, with the new overrides shadowing the parent's.
+func With(ctx context.Context, overrides map[string]string) context.Context {
+	merged := map[string]string{}
+	if parent, ok := ctx.Value(ctxKey{}).(map[string]string); ok {
+		for k, v := range parent {
+			merged[k] = v
+		}
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, ctxKey{}, merged)
+}
+
+// GetCtx is like Get, but consults any overlay attached to ctx via With
+// before falling back to the global env map.
+func GetCtx(ctx context.Context, key string, value string) string {
+	if overlay, ok := ctx.Value(ctxKey{}).(map[string]string); ok {
+		if v, ok := overlay[key]; ok {
+			return v
+		}
+	}
+	return Get(key, value)
+}
+
+// MustGetCtx is like MustGet, but consults any overlay attached to ctx via
+// With before falling back to the global env map.
+func MustGetCtx(ctx context.Context, key string) (string, error) {
+	if overlay, ok := ctx.Value(ctxKey{}).(map[string]string); ok {
+		if v, ok := overlay[key]; ok {
+			return v, nil
+		}
+	}
+	return MustGet(key)
+}
+
+// MapCtx is like Map, but overlays any ENV values attached to ctx via With
+// on top of the global env map.
+func MapCtx(ctx context.Context) map[string]string {
+	cp := Map()
+	if overlay, ok := ctx.Value(ctxKey{}).(map[string]string); ok {
+		for k, v := range overlay {
+			cp[k] = v
+		}
+	}
+	return cp
+}
+
+// EnvironCtx is like Environ, but overlays any ENV values attached to ctx
+// via With on top of the global env map.
+func EnvironCtx(ctx context.Context) []string {
+	m := MapCtx(ctx)
+	e := make([]string, 0, len(m))
+	for k, v := range m {
+		e = append(e, fmt.Sprintf("%s=%s", k, v))
+	}
+	return e
+}
+
+// TempCtx is a context-scoped counterpart to Temp: it attaches overrides to
+// ctx for the duration of fn, without touching the package-global env map,
+// so it is safe to use from concurrent goroutines and parallel tests.
+func TempCtx(ctx context.Context, overrides map[string]string, fn func(ctx context.Context)) {
+	fn(With(ctx, overrides))
+}
@@ -28,8 +28,9 @@ var gil = &sync.RWMutex{}
 var env = map[string]string{}
 
 func init() {
-	Load()
 	loadSystemEnv()
+	Load()
+	loadUserEnv()
 }
 
 // Load the ENV variables to the env map
@@ -68,12 +69,17 @@ func loadSystemEnv() {
 func Reload() {
 	env = map[string]string{}
 	loadSystemEnv()
+	invalidateGoEnv()
 }
 
 // Load .env files. Files will be loaded in the same order that are received.
 // Redefined vars will override previously existing values.
 // IE: envy.Load(".env", "test_env/.env") will result in DIR=test_env
 // If no arg passed, it will try to load a .env file.
+//
+// System env always wins under Load: a key already set in the ENV is left
+// untouched. Use Overload if later files (or the ENV itself) should be
+// overwritten instead.
 func Load(files ...string) error {
 
 	// If no files received, load the default one
@@ -0,0 +1,143 @@
+package envy
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// goEnvVars lists the `go env` variables LoadGoEnv merges into the envy map.
+var goEnvVars = []string{
+	"GOMODCACHE",
+	"GOFLAGS",
+	"GOPROXY",
+	"GOPRIVATE",
+	"GONOSUMCHECK",
+	"GOOS",
+	"GOARCH",
+	"GOROOT",
+	"GOBIN",
+	"GOCACHE",
+	"GOTOOLCHAIN",
+}
+
+var (
+	goEnvMu   sync.Mutex
+	goEnvData map[string]string
+)
+
+// LoadGoEnv shells out to `go env -json` once, caching the result until
+// Reload is called, and merges the Go toolchain variables listed in
+// goEnvVars into the envy map. A variable already set in the OS environment
+// (and therefore already present in the envy map) is left untouched, so
+// callers can still override any of them.
+func LoadGoEnv() error {
+	goEnvMu.Lock()
+	defer goEnvMu.Unlock()
+
+	if goEnvData == nil {
+		data, err := fetchGoEnv()
+		if err != nil {
+			return err
+		}
+		goEnvData = data
+	}
+
+	gil.Lock()
+	defer gil.Unlock()
+	for _, name := range goEnvVars {
+		if _, ok := env[name]; ok {
+			continue
+		}
+		if v, ok := goEnvData[name]; ok && v != "" {
+			env[name] = v
+		}
+	}
+	return nil
+}
+
+func fetchGoEnv() (map[string]string, error) {
+	out, err := exec.Command("go", "env", "-json").Output()
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]string{}
+	if err := json.Unmarshal(out, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// invalidateGoEnv drops the cached `go env` result so the next call that
+// needs it (LoadGoEnv, or one of the typed accessors below) re-fetches it.
+func invalidateGoEnv() {
+	goEnvMu.Lock()
+	goEnvData = nil
+	goEnvMu.Unlock()
+}
+
+// ensureGoEnv best-effort loads the Go toolchain env, swallowing the error:
+// the typed accessors below fall back to "" when `go` isn't on PATH.
+func ensureGoEnv() {
+	_ = LoadGoEnv()
+}
+
+// GoModCache returns GOMODCACHE, loading it from `go env` if necessary.
+func GoModCache() string {
+	ensureGoEnv()
+	return Get("GOMODCACHE", "")
+}
+
+// GoFlags returns GOFLAGS, loading it from `go env` if necessary.
+func GoFlags() string {
+	ensureGoEnv()
+	return Get("GOFLAGS", "")
+}
+
+// GoProxy returns GOPROXY split on commas, loading it from `go env` if
+// necessary.
+func GoProxy() []string {
+	ensureGoEnv()
+	v := Get("GOPROXY", "")
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// GoPrivate returns GOPRIVATE, loading it from `go env` if necessary.
+func GoPrivate() string {
+	ensureGoEnv()
+	return Get("GOPRIVATE", "")
+}
+
+// GoOS returns GOOS, loading it from `go env` if necessary.
+func GoOS() string {
+	ensureGoEnv()
+	return Get("GOOS", "")
+}
+
+// GoArch returns GOARCH, loading it from `go env` if necessary.
+func GoArch() string {
+	ensureGoEnv()
+	return Get("GOARCH", "")
+}
+
+// GoRoot returns GOROOT, loading it from `go env` if necessary.
+func GoRoot() string {
+	ensureGoEnv()
+	return Get("GOROOT", "")
+}
+
+// GoCache returns GOCACHE, loading it from `go env` if necessary.
+func GoCache() string {
+	ensureGoEnv()
+	return Get("GOCACHE", "")
+}
+
+// GoToolchain returns GOTOOLCHAIN, loading it from `go env` if necessary.
+func GoToolchain() string {
+	ensureGoEnv()
+	return Get("GOTOOLCHAIN", "")
+}
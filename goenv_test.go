@@ -0,0 +1,34 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadInvalidatesGoEnvCache(t *testing.T) {
+	goEnvMu.Lock()
+	goEnvData = map[string]string{"GOOS": "sentinel"}
+	goEnvMu.Unlock()
+
+	Reload()
+
+	goEnvMu.Lock()
+	defer goEnvMu.Unlock()
+	require.Nil(t, goEnvData, "Reload must drop the cached go env result")
+}
+
+func TestLoadGoEnvDoesNotOverrideOSSetVar(t *testing.T) {
+	orig := saveEnv()
+	defer restoreEnv(orig)
+	defer invalidateGoEnv()
+
+	t.Setenv("GOPROXY", "https://example.invalid,direct")
+
+	env = map[string]string{}
+	loadSystemEnv()
+	invalidateGoEnv()
+
+	require.NoError(t, LoadGoEnv())
+	require.Equal(t, "https://example.invalid,direct", Get("GOPROXY", ""), "an OS-set var must not be overwritten by the go env merge")
+}
@@ -0,0 +1,21 @@
+package envy
+
+// saveEnv snapshots the package-global env map so a test that replaces it
+// wholesale (env = map[string]string{}) can restore it afterward instead of
+// discarding whatever the previous test left behind.
+func saveEnv() map[string]string {
+	gil.RLock()
+	defer gil.RUnlock()
+	cp := make(map[string]string, len(env))
+	for k, v := range env {
+		cp[k] = v
+	}
+	return cp
+}
+
+// restoreEnv puts back an env map snapshot taken with saveEnv.
+func restoreEnv(m map[string]string) {
+	gil.Lock()
+	defer gil.Unlock()
+	env = m
+}
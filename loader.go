@@ -0,0 +1,169 @@
+package envy
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// Overload loads ENV variables from .env files like Load, except values
+// already set (in the ENV or from an earlier file in the list) are
+// overwritten rather than skipped. Files are processed in order, so later
+// files win. If no files are given, it tries to load a ".env" file.
+func Overload(files ...string) error {
+	if len(files) == 0 {
+		err := godotenv.Overload()
+		if err == nil {
+			Reload()
+		}
+		return err
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			return err
+		}
+		if err := godotenv.Overload(file); err != nil {
+			return err
+		}
+		Reload()
+	}
+	return nil
+}
+
+// MustLoad is like Load, but panics with a descriptive error instead of
+// returning one. Useful in init()/main() where a missing config file is
+// fatal.
+func MustLoad(files ...string) {
+	if err := Load(files...); err != nil {
+		panic(fmt.Sprintf("envy: could not load %v: %v", files, err))
+	}
+}
+
+// MustOverload is like Overload, but panics with a descriptive error
+// instead of returning one.
+func MustOverload(files ...string) {
+	if err := Overload(files...); err != nil {
+		panic(fmt.Sprintf("envy: could not overload %v: %v", files, err))
+	}
+}
+
+// LoadOptions configures LoadWith. It is purely additive: existing callers
+// of Load and Overload are unaffected by its zero value.
+type LoadOptions struct {
+	// Overload, like the Overload function, overwrites already-set keys
+	// instead of skipping them.
+	Overload bool
+
+	// Expand enables opt-in POSIX-style variable expansion: a value like
+	// FOO=${BAR}-suffix resolves BAR against the envy map as it stands at
+	// load time, before this file is loaded. It does not resolve sibling
+	// keys from within the same file.
+	Expand bool
+}
+
+// LoadWith loads files like Load or Overload, depending on opts.Overload,
+// with the extra opt-in behavior described by opts. If no files are given,
+// it tries to load a ".env" file.
+func LoadWith(opts LoadOptions, files ...string) error {
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			return err
+		}
+
+		var raw map[string]string
+		var err error
+		if opts.Expand {
+			// godotenv.Read performs its own variable expansion while
+			// parsing, resolved against the file's own (partially built)
+			// map rather than the envy map. readEnvFileRaw sidesteps that
+			// so expandVars is the only expansion that runs, resolved
+			// solely against the envy map as it stood before this file.
+			raw, err = readEnvFileRaw(file)
+		} else {
+			raw, err = godotenv.Read(file)
+		}
+		if err != nil {
+			return err
+		}
+
+		if opts.Expand {
+			for k, v := range raw {
+				raw[k] = expandVars(v)
+			}
+		}
+
+		gil.Lock()
+		for k, v := range raw {
+			if !opts.Overload {
+				if _, exists := env[k]; exists {
+					continue
+				}
+			}
+			env[k] = v
+			os.Setenv(k, v)
+		}
+		gil.Unlock()
+	}
+	return nil
+}
+
+// readEnvFileRaw parses a .env file into KEY=VALUE pairs without any
+// variable expansion, so the caller can apply its own (see expandVars).
+// It supports the same basic syntax as godotenv: blank lines and lines
+// starting with "#" are ignored, and a value may be wrapped in matching
+// single or double quotes.
+func readEnvFileRaw(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if len(value) > 1 {
+			if (value[0] == '"' && value[len(value)-1] == '"') ||
+				(value[0] == '\'' && value[len(value)-1] == '\'') {
+				value = value[1 : len(value)-1]
+			}
+		}
+
+		out[key] = value
+	}
+	return out, nil
+}
+
+var expandVarRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// expandVars resolves ${NAME} and $NAME references in value against the
+// envy map as it stands before this file is loaded. An unresolved
+// reference expands to "".
+func expandVars(value string) string {
+	return expandVarRegex.ReplaceAllStringFunc(value, func(m string) string {
+		sub := expandVarRegex.FindStringSubmatch(m)
+		name := sub[1]
+		if name == "" {
+			name = sub[2]
+		}
+		return Get(name, "")
+	})
+}
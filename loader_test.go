@@ -0,0 +1,31 @@
+package envy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadWithExpandResolvesAgainstEnvyMapOnly pins that expansion is
+// deterministic: it resolves only against the envy map as it stood before
+// the file was loaded, never against sibling keys in the same file.
+func TestLoadWithExpandResolvesAgainstEnvyMapOnly(t *testing.T) {
+	orig := saveEnv()
+	defer restoreEnv(orig)
+
+	env = map[string]string{}
+	loadSystemEnv()
+	Set("A", "1")
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(file, []byte("B=${A}\nC=${B}-x\n"), 0o644))
+	t.Cleanup(func() { os.Unsetenv("B"); os.Unsetenv("C") })
+
+	require.NoError(t, LoadWith(LoadOptions{Expand: true}, file))
+
+	require.Equal(t, "1", Get("B", ""))
+	require.Equal(t, "-x", Get("C", ""), "C must not see B's same-file expansion, only the prior envy map")
+}
@@ -0,0 +1,230 @@
+package envy
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Value lists the types that may be passed to Register.
+type Value interface {
+	string | bool | int | float64 | time.Duration | []string
+}
+
+// Option configures a Var at Register time.
+type Option func(*varInfo)
+
+// Required marks the variable as mandatory: if it is unset, or the raw value
+// found in the ENV fails validation or to parse as T, Get panics instead of
+// logging and falling back to the default.
+func Required() Option {
+	return func(vi *varInfo) { vi.required = true }
+}
+
+// Validate attaches a validator that runs against the raw string value
+// before it is parsed. A non-nil error is treated the same as a parse
+// error: it panics if Required, otherwise it is logged once and the
+// default is used.
+func Validate(fn func(raw string) error) Option {
+	return func(vi *varInfo) { vi.validator = fn }
+}
+
+// DeprecatedAlias registers an old ENV var name that should still be
+// honored, with a one-time warning logged the first time it is used.
+func DeprecatedAlias(name string) Option {
+	return func(vi *varInfo) { vi.aliases = append(vi.aliases, name) }
+}
+
+type varInfo struct {
+	name        string
+	def         string
+	description string
+	required    bool
+	validator   func(string) error
+	aliases     []string
+
+	warnOnce sync.Once
+	failOnce sync.Once
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*varInfo{}
+)
+
+// Var is a typed, registered ENV variable. Use Register to create one.
+type Var[T Value] struct {
+	info *varInfo
+	def  T
+}
+
+// Register declares an ENV variable of type T, recording its name, default
+// value, and description in the package-level registry so it can later be
+// listed with Describe or PrintDefaults. Parsing is lazy: the raw string is
+// only read from the ENV (and parsed into T) when Get or Lookup is called.
+func Register[T Value](name string, def T, desc string, opts ...Option) *Var[T] {
+	info := &varInfo{
+		name:        name,
+		def:         fmt.Sprintf("%v", def),
+		description: desc,
+	}
+	for _, opt := range opts {
+		opt(info)
+	}
+
+	registryMu.Lock()
+	registry[name] = info
+	registryMu.Unlock()
+
+	return &Var[T]{info: info, def: def}
+}
+
+// Get returns the current value of the variable, falling back to its
+// default if it is unset or fails validation/parsing.
+func (v *Var[T]) Get() T {
+	val, ok := v.Lookup()
+	if !ok {
+		return v.def
+	}
+	return val
+}
+
+// Lookup returns the current value of the variable and whether it was
+// found and successfully parsed. A false return with a Required var
+// panics rather than returning; see Required.
+func (v *Var[T]) Lookup() (T, bool) {
+	raw, ok := lookupRaw(v.info)
+	if !ok {
+		if v.info.required {
+			return v.fail(fmt.Errorf("not set"))
+		}
+		return v.def, false
+	}
+
+	if v.info.validator != nil {
+		if err := v.info.validator(raw); err != nil {
+			return v.fail(err)
+		}
+	}
+
+	parsed, err := parseValue[T](raw)
+	if err != nil {
+		return v.fail(err)
+	}
+	return parsed, true
+}
+
+func (v *Var[T]) fail(err error) (T, bool) {
+	if v.info.required {
+		panic(fmt.Sprintf("envy: required var %q: %v", v.info.name, err))
+	}
+	v.info.failOnce.Do(func() {
+		log.Printf("envy: ignoring invalid value for %q: %v", v.info.name, err)
+	})
+	return v.def, false
+}
+
+// lookupRaw reads the raw string value for info.name from the ENV, falling
+// back to any deprecated aliases in order.
+func lookupRaw(info *varInfo) (string, bool) {
+	if raw, err := MustGet(info.name); err == nil {
+		return raw, true
+	}
+	for _, alias := range info.aliases {
+		if raw, err := MustGet(alias); err == nil {
+			info.warnOnce.Do(func() {
+				log.Printf("envy: %q is deprecated, use %q instead", alias, info.name)
+			})
+			return raw, true
+		}
+	}
+	return "", false
+}
+
+// parseValue parses raw into T, dispatching on T's underlying type.
+func parseValue[T Value](raw string) (T, error) {
+	var zero T
+	switch any(zero).(type) {
+	case string:
+		return any(raw).(T), nil
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return zero, err
+		}
+		return any(b).(T), nil
+	case int:
+		i, err := strconv.Atoi(raw)
+		if err != nil {
+			return zero, err
+		}
+		return any(i).(T), nil
+	case float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return zero, err
+		}
+		return any(f).(T), nil
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return zero, err
+		}
+		return any(d).(T), nil
+	case []string:
+		return any(strings.Split(raw, ",")).(T), nil
+	default:
+		return zero, fmt.Errorf("envy: unsupported type %T", zero)
+	}
+}
+
+// VarInfo is a snapshot of a registered variable's metadata, as returned by
+// Describe.
+type VarInfo struct {
+	Name        string
+	Default     string
+	Description string
+	Required    bool
+	Aliases     []string
+}
+
+// Describe returns metadata for every variable registered with Register,
+// sorted by name.
+func Describe() []VarInfo {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	out := make([]VarInfo, 0, len(registry))
+	for _, info := range registry {
+		out = append(out, VarInfo{
+			Name:        info.name,
+			Default:     info.def,
+			Description: info.description,
+			Required:    info.required,
+			Aliases:     append([]string(nil), info.aliases...),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// PrintDefaults writes a human-readable listing of every registered
+// variable to w, in the style of flag.PrintDefaults. Applications can wire
+// this up behind a `--help-env` flag.
+func PrintDefaults(w io.Writer) {
+	for _, vi := range Describe() {
+		fmt.Fprintf(w, "  %s\n    \t%s (default %q)", vi.Name, vi.Description, vi.Default)
+		if vi.Required {
+			fmt.Fprint(w, " [required]")
+		}
+		if len(vi.Aliases) > 0 {
+			fmt.Fprintf(w, " (deprecated aliases: %s)", strings.Join(vi.Aliases, ", "))
+		}
+		fmt.Fprintln(w)
+	}
+}
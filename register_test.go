@@ -0,0 +1,23 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterRequiredPanicsWhenUnset(t *testing.T) {
+	v := Register("CHUNK0_2_UNSET_REQUIRED_VAR", "default", "a required var that is never set", Required())
+
+	assert.Panics(t, func() {
+		v.Get()
+	})
+}
+
+func TestRegisterOptionalFallsBackWhenUnset(t *testing.T) {
+	v := Register("CHUNK0_2_UNSET_OPTIONAL_VAR", "default", "an optional var that is never set")
+
+	got := v.Get()
+	require.Equal(t, "default", got)
+}
@@ -0,0 +1,104 @@
+package envy
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/joho/godotenv"
+)
+
+// userEnvPath returns the path to the persistent user env file: the
+// ENVY_ENV override if set, otherwise <UserConfigDir>/envy/env.
+func userEnvPath() (string, error) {
+	if p := os.Getenv("ENVY_ENV"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "envy", "env"), nil
+}
+
+// loadUserEnv loads the persistent user env file, if any, into the ENV. It
+// runs after loadSystemEnv and after any project .env file has already been
+// loaded, so its entries only fill in vars the system and the project left
+// unset - a project's own .env always wins over a user-wide default. A
+// missing file is not an error.
+func loadUserEnv() {
+	path, err := userEnvPath()
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	if err := godotenv.Load(path); err == nil {
+		Reload()
+	}
+}
+
+// DefaultEnv reads and returns the contents of the persistent user env
+// file as a map. It returns an empty map if the file does not exist.
+func DefaultEnv() map[string]string {
+	path, err := userEnvPath()
+	if err != nil {
+		return map[string]string{}
+	}
+	m, err := godotenv.Read(path)
+	if err != nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// WriteDefault sets key to value in the persistent user env file,
+// creating it (and its parent directory) if necessary. The file is
+// updated atomically: it is read, modified in memory, and written to a
+// temp file that is then renamed into place.
+func WriteDefault(key, value string) error {
+	path, err := userEnvPath()
+	if err != nil {
+		return err
+	}
+
+	m, err := godotenv.Read(path)
+	if err != nil {
+		m = map[string]string{}
+	}
+	m[key] = value
+
+	return writeDefaultEnvFile(path, m)
+}
+
+// UnsetDefault removes key from the persistent user env file, if present.
+// It is a no-op if the file or the key does not exist.
+func UnsetDefault(key string) error {
+	path, err := userEnvPath()
+	if err != nil {
+		return err
+	}
+
+	m, err := godotenv.Read(path)
+	if err != nil {
+		return nil
+	}
+	if _, ok := m[key]; !ok {
+		return nil
+	}
+	delete(m, key)
+
+	return writeDefaultEnvFile(path, m)
+}
+
+func writeDefaultEnvFile(path string, m map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := godotenv.Write(m, tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,39 @@
+package envy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserEnvPrecedence pins the precedence the [gochigo/envy#chunk0-4]
+// request asked for: the user-wide default file only fills in vars the
+// project's own .env left unset, it never overrides them.
+func TestUserEnvPrecedence(t *testing.T) {
+	dir := t.TempDir()
+
+	projectFile := filepath.Join(dir, "project.env")
+	require.NoError(t, os.WriteFile(projectFile, []byte("FOO=from-project-dotenv\n"), 0o644))
+
+	userFile := filepath.Join(dir, "user.env")
+	require.NoError(t, os.WriteFile(userFile, []byte("FOO=from-user-default\nBAR=from-user-default\n"), 0o644))
+
+	t.Setenv("ENVY_ENV", userFile)
+	t.Cleanup(func() {
+		os.Unsetenv("FOO")
+		os.Unsetenv("BAR")
+	})
+
+	orig := saveEnv()
+	defer restoreEnv(orig)
+
+	env = map[string]string{}
+	loadSystemEnv()
+	require.NoError(t, Load(projectFile))
+	loadUserEnv()
+
+	require.Equal(t, "from-project-dotenv", Get("FOO", ""), "project .env must win over a user default")
+	require.Equal(t, "from-user-default", Get("BAR", ""), "user default must fill in vars the project left unset")
+}